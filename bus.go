@@ -0,0 +1,28 @@
+package i2c
+
+// Bus is the subset of *I2C's behavior that driver code built on top
+// of go-i2c depends on. Code that takes a Bus instead of a concrete
+// *I2C can be exercised against the mocki2c package in unit tests,
+// without real hardware.
+type Bus interface {
+	WriteBytes(buf []byte) (int, error)
+	ReadBytes(buf []byte) (int, error)
+	Tx(w, r []byte) error
+	TxAddr(addr uint8, w, r []byte) error
+
+	ReadRegBytes(reg byte, n int) ([]byte, int, error)
+	ReadRegU8(reg byte) (byte, error)
+	WriteRegU8(reg byte, value byte) error
+	ReadRegU16BE(reg byte) (uint16, error)
+	ReadRegU16LE(reg byte) (uint16, error)
+	ReadRegS16BE(reg byte) (int16, error)
+	ReadRegS16LE(reg byte) (int16, error)
+	WriteRegU16BE(reg byte, value uint16) error
+	WriteRegU16LE(reg byte, value uint16) error
+	WriteRegS16BE(reg byte, value int16) error
+	WriteRegS16LE(reg byte, value int16) error
+
+	Close() error
+}
+
+var _ Bus = (*I2C)(nil)
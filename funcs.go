@@ -0,0 +1,64 @@
+package i2c
+
+import "unsafe"
+
+const (
+	// i2cFuncs queries the set of functionality an adapter supports.
+	i2cFuncs = 0x0705
+)
+
+// Adapter functionality bits, as returned by I2C_FUNCS. See
+// <linux/i2c.h> / <linux/i2c-dev.h> for the authoritative list.
+const (
+	I2CFuncI2C             = 0x00000001
+	I2CFunc10BitAddr       = 0x00000002
+	I2CFuncNostart         = 0x00000010
+	I2CFuncSMBusByte       = 0x00020000 | 0x00040000 // READ_BYTE | WRITE_BYTE
+	I2CFuncSMBusByteData   = 0x00080000 | 0x00100000 // READ_BYTE_DATA | WRITE_BYTE_DATA
+	I2CFuncSMBusWordData   = 0x00200000 | 0x00400000 // READ_WORD_DATA | WRITE_WORD_DATA
+	I2CFuncSMBusBlockData  = 0x01000000 | 0x02000000 // READ_BLOCK_DATA | WRITE_BLOCK_DATA
+	I2CFuncSMBusReadI2CBlk = 0x04000000
+)
+
+// Funcs issues the I2C_FUNCS ioctl and returns the bitmask of
+// capabilities the underlying adapter supports.
+func (v *I2C) Funcs() (uint32, error) {
+	v.bus.mu.Lock()
+	defer v.bus.mu.Unlock()
+
+	var funcs uint32
+	if err := ioctl(v.bus.f.Fd(), i2cFuncs, uintptr(unsafe.Pointer(&funcs))); err != nil {
+		return 0, err
+	}
+	return funcs, nil
+}
+
+// SupportsRDWR reports whether the adapter can perform raw I2C_RDWR
+// transfers, as used by Tx/TxAddr.
+func (v *I2C) SupportsRDWR() (bool, error) {
+	funcs, err := v.Funcs()
+	if err != nil {
+		return false, err
+	}
+	return funcs&I2CFuncI2C != 0, nil
+}
+
+// SupportsSMBusBlock reports whether the adapter supports SMBus block
+// transfers.
+func (v *I2C) SupportsSMBusBlock() (bool, error) {
+	funcs, err := v.Funcs()
+	if err != nil {
+		return false, err
+	}
+	return funcs&I2CFuncSMBusBlockData != 0, nil
+}
+
+// Supports10BitAddr reports whether the adapter supports 10-bit slave
+// addresses.
+func (v *I2C) Supports10BitAddr() (bool, error) {
+	funcs, err := v.Funcs()
+	if err != nil {
+		return false, err
+	}
+	return funcs&I2CFunc10BitAddr != 0, nil
+}
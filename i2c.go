@@ -10,8 +10,6 @@ package i2c
 
 import (
 	"encoding/hex"
-	"fmt"
-	"os"
 	"syscall"
 
 	"github.com/sirupsen/logrus"
@@ -22,25 +20,32 @@ const (
 	i2cSlave = 0x0703
 )
 
-// I2C represents a connection to an i2c device.
+// I2C represents a connection to an i2c device. The underlying fd is
+// shared with every other handle opened against the same bus (see
+// Open), so concurrent handles addressing different slaves don't race
+// on I2C_SLAVE.
 type I2C struct {
-	rc *os.File
+	bus    *busFD
+	busNum int
+	addr   uint8  // 7-bit slave address; unused when tenBit is set
+	addr16 uint16 // 10-bit slave address; only valid when tenBit is set
+	tenBit bool
 	// Logger
 	log *logrus.Logger
 }
 
+// selectedAddr returns the slave address this handle talks to, in
+// whichever of the 7-bit/10-bit forms it was opened with.
+func (v *I2C) selectedAddr() (addr uint16, tenBit bool) {
+	if v.tenBit {
+		return v.addr16, true
+	}
+	return uint16(v.addr), false
+}
+
 // NewI2C opens a connection to an i2c device.
 func NewI2C(addr uint8, bus int) (*I2C, error) {
-	f, err := os.OpenFile(fmt.Sprintf("/dev/i2c-%d", bus), os.O_RDWR, 0600)
-	if err != nil {
-		return nil, err
-	}
-	if err := ioctl(f.Fd(), i2cSlave, uintptr(addr)); err != nil {
-		return nil, err
-	}
-	v := &I2C{rc: f}
-	v.log = logrus.New()
-	return v, nil
+	return Open(bus, addr)
 }
 
 //SetLogger set logger
@@ -49,12 +54,18 @@ func (v *I2C) SetLogger(log *logrus.Logger) {
 }
 
 func (v *I2C) write(buf []byte) (int, error) {
-	return v.rc.Write(buf)
+	return v.bus.f.Write(buf)
 }
 
 // WriteBytes sends buf to the remote i2c device. The interpretation of
 // the message is implementation dependant.
 func (v *I2C) WriteBytes(buf []byte) (int, error) {
+	v.bus.mu.Lock()
+	defer v.bus.mu.Unlock()
+
+	if err := v.setSlaveAddr(v.selectedAddr()); err != nil {
+		return 0, err
+	}
 	v.log.Debugf("Write %d hex bytes: [%+v]", len(buf), hex.EncodeToString(buf))
 	return v.write(buf)
 }
@@ -64,11 +75,17 @@ func (v *I2C) WriteBytes(buf []byte) (int, error) {
 // }
 
 func (v *I2C) read(buf []byte) (int, error) {
-	return v.rc.Read(buf)
+	return v.bus.f.Read(buf)
 }
 
 // ReadBytes ready byte
 func (v *I2C) ReadBytes(buf []byte) (int, error) {
+	v.bus.mu.Lock()
+	defer v.bus.mu.Unlock()
+
+	if err := v.setSlaveAddr(v.selectedAddr()); err != nil {
+		return 0, err
+	}
 	n, err := v.read(buf)
 	if err != nil {
 		return n, err
@@ -79,7 +96,7 @@ func (v *I2C) ReadBytes(buf []byte) (int, error) {
 
 // Close given connection
 func (v *I2C) Close() error {
-	return v.rc.Close()
+	return closeBus(v.busNum, v.bus)
 }
 
 // ReadRegBytes SMBus (System Management Bus) protocol over I2C.
@@ -88,29 +105,18 @@ func (v *I2C) Close() error {
 func (v *I2C) ReadRegBytes(reg byte, n int) ([]byte, int, error) {
 	v.log.Debugf("Read %d bytes starting from reg 0x%0X...", n, reg)
 
-	_, err := v.WriteBytes([]byte{reg})
-	if err != nil {
-		return nil, 0, err
-	}
 	buf := make([]byte, n)
-	c, err := v.ReadBytes(buf)
-	if err != nil {
+	if err := v.Tx([]byte{reg}, buf); err != nil {
 		return nil, 0, err
 	}
-	return buf, c, nil
-
+	return buf, n, nil
 }
 
 // ReadRegU8 SMBus (System Management Bus) protocol over I2C.
 // Read byte from i2c device register specified in reg.
 func (v *I2C) ReadRegU8(reg byte) (byte, error) {
-	_, err := v.WriteBytes([]byte{reg})
-	if err != nil {
-		return 0, err
-	}
 	buf := make([]byte, 1)
-	_, err = v.ReadBytes(buf)
-	if err != nil {
+	if err := v.Tx([]byte{reg}, buf); err != nil {
 		return 0, err
 	}
 
@@ -135,13 +141,8 @@ func (v *I2C) WriteRegU8(reg byte, value byte) error {
 // Read unsigned big endian word (16 bits) from i2c device
 // starting from address specified in reg.
 func (v *I2C) ReadRegU16BE(reg byte) (uint16, error) {
-	_, err := v.WriteBytes([]byte{reg})
-	if err != nil {
-		return 0, err
-	}
 	buf := make([]byte, 2)
-	_, err = v.ReadBytes(buf)
-	if err != nil {
+	if err := v.Tx([]byte{reg}, buf); err != nil {
 		return 0, err
 	}
 	w := uint16(buf[0])<<8 + uint16(buf[1])
@@ -167,13 +168,8 @@ func (v *I2C) ReadRegU16LE(reg byte) (uint16, error) {
 // Read signed big endian word (16 bits) from i2c device
 // starting from address specified in reg.
 func (v *I2C) ReadRegS16BE(reg byte) (int16, error) {
-	_, err := v.WriteBytes([]byte{reg})
-	if err != nil {
-		return 0, err
-	}
 	buf := make([]byte, 2)
-	_, err = v.ReadBytes(buf)
-	if err != nil {
+	if err := v.Tx([]byte{reg}, buf); err != nil {
 		return 0, err
 	}
 	w := int16(buf[0])<<8 + int16(buf[1])
@@ -0,0 +1,5 @@
+package mocki2c
+
+import i2c "github.com/16n/go-i2c"
+
+var _ i2c.Bus = (*Handle)(nil)
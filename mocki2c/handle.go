@@ -0,0 +1,128 @@
+package mocki2c
+
+// Handle is a mock stand-in for *i2c.I2C, bound to a single slave
+// address on a Bus. It satisfies i2c.Bus, so it can be passed anywhere
+// a driver expects a live connection.
+type Handle struct {
+	bus  *Bus
+	addr uint8
+}
+
+// Tx dispatches to the device registered at this handle's address.
+func (h *Handle) Tx(w, r []byte) error {
+	dev, err := h.bus.device(h.addr)
+	if err != nil {
+		return err
+	}
+	return dev.Tx(w, r)
+}
+
+// TxAddr dispatches to the device registered at addr, regardless of
+// the address this handle is bound to.
+func (h *Handle) TxAddr(addr uint8, w, r []byte) error {
+	dev, err := h.bus.device(addr)
+	if err != nil {
+		return err
+	}
+	return dev.Tx(w, r)
+}
+
+// WriteBytes sends buf as a write-only transaction.
+func (h *Handle) WriteBytes(buf []byte) (int, error) {
+	if err := h.Tx(buf, nil); err != nil {
+		return 0, err
+	}
+	return len(buf), nil
+}
+
+// ReadBytes reads len(buf) bytes as a read-only transaction.
+func (h *Handle) ReadBytes(buf []byte) (int, error) {
+	if err := h.Tx(nil, buf); err != nil {
+		return 0, err
+	}
+	return len(buf), nil
+}
+
+// ReadRegBytes reads n bytes starting from reg.
+func (h *Handle) ReadRegBytes(reg byte, n int) ([]byte, int, error) {
+	buf := make([]byte, n)
+	if err := h.Tx([]byte{reg}, buf); err != nil {
+		return nil, 0, err
+	}
+	return buf, n, nil
+}
+
+// ReadRegU8 reads one byte from reg.
+func (h *Handle) ReadRegU8(reg byte) (byte, error) {
+	buf := make([]byte, 1)
+	if err := h.Tx([]byte{reg}, buf); err != nil {
+		return 0, err
+	}
+	return buf[0], nil
+}
+
+// WriteRegU8 writes value to reg.
+func (h *Handle) WriteRegU8(reg byte, value byte) error {
+	return h.Tx([]byte{reg, value}, nil)
+}
+
+// ReadRegU16BE reads a big endian word (16 bits) starting from reg.
+func (h *Handle) ReadRegU16BE(reg byte) (uint16, error) {
+	buf := make([]byte, 2)
+	if err := h.Tx([]byte{reg}, buf); err != nil {
+		return 0, err
+	}
+	return uint16(buf[0])<<8 + uint16(buf[1]), nil
+}
+
+// ReadRegU16LE reads a little endian word (16 bits) starting from reg.
+func (h *Handle) ReadRegU16LE(reg byte) (uint16, error) {
+	w, err := h.ReadRegU16BE(reg)
+	if err != nil {
+		return 0, err
+	}
+	return (w&0xFF)<<8 + w>>8, nil
+}
+
+// ReadRegS16BE reads a signed big endian word (16 bits) starting from reg.
+func (h *Handle) ReadRegS16BE(reg byte) (int16, error) {
+	buf := make([]byte, 2)
+	if err := h.Tx([]byte{reg}, buf); err != nil {
+		return 0, err
+	}
+	return int16(buf[0])<<8 + int16(buf[1]), nil
+}
+
+// ReadRegS16LE reads a signed little endian word (16 bits) starting from reg.
+func (h *Handle) ReadRegS16LE(reg byte) (int16, error) {
+	w, err := h.ReadRegS16BE(reg)
+	if err != nil {
+		return 0, err
+	}
+	return int16(uint16(w)<<8 | uint16(w)>>8), nil
+}
+
+// WriteRegU16BE writes a big endian word (16 bits) to reg.
+func (h *Handle) WriteRegU16BE(reg byte, value uint16) error {
+	return h.Tx([]byte{reg, byte(value >> 8), byte(value)}, nil)
+}
+
+// WriteRegU16LE writes a little endian word (16 bits) to reg.
+func (h *Handle) WriteRegU16LE(reg byte, value uint16) error {
+	w := value>>8 + value<<8
+	return h.WriteRegU16BE(reg, w)
+}
+
+// WriteRegS16BE writes a signed big endian word (16 bits) to reg.
+func (h *Handle) WriteRegS16BE(reg byte, value int16) error {
+	return h.Tx([]byte{reg, byte(uint16(value) >> 8), byte(value)}, nil)
+}
+
+// WriteRegS16LE writes a signed little endian word (16 bits) to reg.
+func (h *Handle) WriteRegS16LE(reg byte, value int16) error {
+	w := int16(uint16(value)>>8 | uint16(value)<<8)
+	return h.WriteRegS16BE(reg, w)
+}
+
+// Close is a no-op; mock handles don't own a real fd.
+func (h *Handle) Close() error { return nil }
@@ -0,0 +1,47 @@
+// Package mocki2c provides an in-memory implementation of i2c.Bus for
+// unit-testing drivers built on top of go-i2c without real hardware.
+// It's modeled after the TinyGo tester package: each mock device
+// implements a Tx method, and a Bus dispatches incoming transactions
+// to whichever device is registered for the message's 7-bit address.
+package mocki2c
+
+import "fmt"
+
+// Device is a single mock i2c slave, addressed by the Bus it's
+// registered on.
+type Device interface {
+	Tx(w, r []byte) error
+}
+
+// Bus is a collection of mock devices, dispatching by 7-bit address.
+// Use WithAddr to get an i2c.Bus-shaped handle bound to one of them.
+type Bus struct {
+	devices map[uint8]Device
+}
+
+// NewBus creates an empty mock bus. Register devices on it with
+// Register before exercising the code under test.
+func NewBus() *Bus {
+	return &Bus{devices: map[uint8]Device{}}
+}
+
+// Register attaches dev at the given 7-bit address, replacing
+// whatever was previously registered there.
+func (b *Bus) Register(addr uint8, dev Device) {
+	b.devices[addr] = dev
+}
+
+// WithAddr returns a handle bound to addr, mirroring how *i2c.I2C
+// binds a single slave address per handle. Its methods implement
+// i2c.Bus, dispatching to whichever Device was Register'd at addr.
+func (b *Bus) WithAddr(addr uint8) *Handle {
+	return &Handle{bus: b, addr: addr}
+}
+
+func (b *Bus) device(addr uint8) (Device, error) {
+	dev, ok := b.devices[addr]
+	if !ok {
+		return nil, fmt.Errorf("mocki2c: no device registered at address 0x%0X", addr)
+	}
+	return dev, nil
+}
@@ -0,0 +1,120 @@
+package mocki2c_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	i2c "github.com/16n/go-i2c"
+	"github.com/16n/go-i2c/mocki2c"
+)
+
+func TestBusDispatchesByAddress(t *testing.T) {
+	bus := mocki2c.NewBus()
+	bus.Register(0x50, mocki2c.NewRegDevice(0x50, map[byte]byte{0x00: 0x11, 0x01: 0x22}))
+	bus.Register(0x51, mocki2c.NewRegDevice(0x51, map[byte]byte{0x00: 0x33}))
+
+	var a, b i2c.Bus = bus.WithAddr(0x50), bus.WithAddr(0x51)
+
+	got, err := a.ReadRegU8(0x01)
+	if err != nil || got != 0x22 {
+		t.Fatalf("device 0x50 reg 0x01 = %#x, %v, want 0x22, nil", got, err)
+	}
+	got, err = b.ReadRegU8(0x00)
+	if err != nil || got != 0x33 {
+		t.Fatalf("device 0x51 reg 0x00 = %#x, %v, want 0x33, nil", got, err)
+	}
+}
+
+func TestBusUnregisteredAddress(t *testing.T) {
+	bus := mocki2c.NewBus()
+	h := bus.WithAddr(0x50)
+	if _, err := h.ReadRegU8(0x00); err == nil {
+		t.Fatal("ReadRegU8 against an unregistered address: got nil error, want one")
+	}
+}
+
+func TestRegDeviceReadWriteAndAutoIncrement(t *testing.T) {
+	dev := mocki2c.NewRegDevice(0x50, map[byte]byte{0x00: 0xAA, 0x01: 0xBB})
+	h := mocki2c.NewBus()
+	h.Register(0x50, dev)
+	var bus i2c.Bus = h.WithAddr(0x50)
+
+	if err := bus.WriteRegU8(0x00, 0x42); err != nil {
+		t.Fatalf("WriteRegU8: %v", err)
+	}
+	if got := dev.Get(0x00); got != 0x42 {
+		t.Fatalf("dev.Get(0x00) = %#x, want 0x42", got)
+	}
+
+	buf, _, err := bus.ReadRegBytes(0x00, 2)
+	if err != nil {
+		t.Fatalf("ReadRegBytes: %v", err)
+	}
+	if want := []byte{0x42, 0xBB}; string(buf) != string(want) {
+		t.Fatalf("ReadRegBytes(0x00, 2) = %#v, want %#v (auto-increment)", buf, want)
+	}
+}
+
+func TestRegDevice16BigEndian(t *testing.T) {
+	dev := mocki2c.NewRegDevice16(0x68, map[byte]uint16{0x10: 0x1234})
+	bus := mocki2c.NewBus()
+	bus.Register(0x68, dev)
+	h := bus.WithAddr(0x68)
+
+	got, err := h.ReadRegU16BE(0x10)
+	if err != nil || got != 0x1234 {
+		t.Fatalf("ReadRegU16BE(0x10) = %#x, %v, want 0x1234, nil", got, err)
+	}
+
+	if err := h.WriteRegU16BE(0x10, 0xCAFE); err != nil {
+		t.Fatalf("WriteRegU16BE: %v", err)
+	}
+	if got := dev.Get(0x10); got != 0xCAFE {
+		t.Fatalf("dev.Get(0x10) = %#x, want 0xCAFE", got)
+	}
+}
+
+func TestScriptedDeviceMatchesScript(t *testing.T) {
+	dev := mocki2c.NewScriptedDevice(t, 0x76, []mocki2c.Transaction{
+		{Write: []byte{0xD0}, Response: []byte{0x60}},
+		{Write: []byte{0xF4}, Response: []byte{0x01, 0x02}},
+	})
+
+	buf := make([]byte, 1)
+	if err := dev.Tx([]byte{0xD0}, buf); err != nil || buf[0] != 0x60 {
+		t.Fatalf("transaction 1: buf=%#v err=%v, want [0x60] nil", buf, err)
+	}
+
+	buf = make([]byte, 2)
+	if err := dev.Tx([]byte{0xF4}, buf); err != nil || string(buf) != "\x01\x02" {
+		t.Fatalf("transaction 2: buf=%#v err=%v, want [0x01 0x02] nil", buf, err)
+	}
+}
+
+// TestScriptedDeviceMismatch is only meaningful run as a subprocess: a
+// write that doesn't match the script calls t.Fatalf, which must fail
+// the test. TestScriptedDeviceMismatchFailsTest drives it and checks
+// that it does.
+func TestScriptedDeviceMismatch(t *testing.T) {
+	if os.Getenv("MOCKI2C_RUN_MISMATCH") != "1" {
+		t.Skip("only runs as a subprocess of TestScriptedDeviceMismatchFailsTest")
+	}
+	dev := mocki2c.NewScriptedDevice(t, 0x76, []mocki2c.Transaction{
+		{Write: []byte{0xD0}, Response: []byte{0x60}},
+	})
+	dev.Tx([]byte{0xFF}, make([]byte, 1))
+}
+
+func TestScriptedDeviceMismatchFailsTest(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestScriptedDeviceMismatch$", "-test.v")
+	cmd.Env = append(os.Environ(), "MOCKI2C_RUN_MISMATCH=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("ScriptedDevice write mismatch did not fail the test; output:\n%s", out)
+	}
+	if !strings.Contains(string(out), "write") {
+		t.Fatalf("expected failure output to mention the write mismatch, got:\n%s", out)
+	}
+}
@@ -0,0 +1,57 @@
+package mocki2c
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Transaction is one expected (write, response) pair for a
+// ScriptedDevice.
+type Transaction struct {
+	Write    []byte
+	Response []byte
+}
+
+// ScriptedDevice replays a fixed script of expected writes and their
+// responses, in order, failing the test via t.Fatalf on the first
+// write mismatch, response-length mismatch, or transaction run past
+// the end of the script.
+type ScriptedDevice struct {
+	t      *testing.T
+	addr   byte
+	script []Transaction
+	pos    int
+}
+
+// NewScriptedDevice returns a Device that expects the (write, response)
+// pairs in script, in order. Script entries that are never reached
+// are not reported.
+func NewScriptedDevice(t *testing.T, addr uint8, script []Transaction) *ScriptedDevice {
+	return &ScriptedDevice{t: t, addr: addr, script: script}
+}
+
+// Tx implements Device.
+func (d *ScriptedDevice) Tx(w, r []byte) error {
+	d.t.Helper()
+
+	if d.pos >= len(d.script) {
+		d.t.Fatalf("mocki2c: ScriptedDevice 0x%0X: unexpected transaction %d (write %#v), script exhausted",
+			d.addr, d.pos, w)
+		return nil
+	}
+	tx := d.script[d.pos]
+	d.pos++
+
+	if !bytes.Equal(w, tx.Write) {
+		d.t.Fatalf("mocki2c: ScriptedDevice 0x%0X: transaction %d write = %#v, want %#v",
+			d.addr, d.pos-1, w, tx.Write)
+		return nil
+	}
+	if len(r) != len(tx.Response) {
+		d.t.Fatalf("mocki2c: ScriptedDevice 0x%0X: transaction %d read length = %d, want %d",
+			d.addr, d.pos-1, len(r), len(tx.Response))
+		return nil
+	}
+	copy(r, tx.Response)
+	return nil
+}
@@ -0,0 +1,127 @@
+package i2c
+
+import (
+	"encoding/hex"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// i2cRdwr issues a combined transfer of one or more i2c_msg's.
+	i2cRdwr = 0x0707
+
+	// i2cMRd marks an i2c_msg as a read; the zero value is a write.
+	i2cMRd = 0x0001
+
+	// i2cMTen marks an i2c_msg's addr as a 10-bit address.
+	i2cMTen = 0x0010
+)
+
+// i2cMsg mirrors the kernel's struct i2c_msg (see <linux/i2c.h>).
+type i2cMsg struct {
+	addr  uint16
+	flags uint16
+	len   uint16
+	buf   uintptr
+}
+
+// i2cRdwrData mirrors the kernel's struct i2c_rdwr_ioctl_data.
+type i2cRdwrData struct {
+	msgs  uintptr
+	nmsgs uint32
+}
+
+// Tx writes w, then reads len(r) bytes into r, as a single I2C_RDWR
+// transaction with a repeated START between the write and the read.
+// Either w or r may be empty. Falls back to a plain Write/Read if the
+// adapter doesn't support I2C_RDWR.
+func (v *I2C) Tx(w, r []byte) error {
+	v.bus.mu.Lock()
+	defer v.bus.mu.Unlock()
+
+	addr, tenBit := v.selectedAddr()
+	if err := v.setSlaveAddr(addr, tenBit); err != nil {
+		return err
+	}
+	return v.tx(addr, tenBit, w, r)
+}
+
+// TxAddr is like Tx, but addresses a 7-bit slave other than the one
+// this handle was opened for.
+func (v *I2C) TxAddr(addr uint8, w, r []byte) error {
+	v.bus.mu.Lock()
+	defer v.bus.mu.Unlock()
+
+	if err := v.setSlave(addr); err != nil {
+		return err
+	}
+	return v.tx(uint16(addr), false, w, r)
+}
+
+// tx issues the I2C_RDWR ioctl. Callers must hold v.bus.mu and have
+// already selected the slave address via setSlaveAddr.
+func (v *I2C) tx(addr uint16, tenBit bool, w, r []byte) error {
+	v.log.Debugf("Tx addr 0x%0X: write %d hex bytes [%+v], read %d bytes",
+		addr, len(w), hex.EncodeToString(w), len(r))
+
+	var msgFlags uint16
+	if tenBit {
+		msgFlags |= i2cMTen
+	}
+
+	var msgs []i2cMsg
+	if len(w) > 0 {
+		msgs = append(msgs, i2cMsg{
+			addr:  addr,
+			flags: msgFlags,
+			len:   uint16(len(w)),
+			buf:   uintptr(unsafe.Pointer(&w[0])),
+		})
+	}
+	if len(r) > 0 {
+		msgs = append(msgs, i2cMsg{
+			addr:  addr,
+			flags: msgFlags | i2cMRd,
+			len:   uint16(len(r)),
+			buf:   uintptr(unsafe.Pointer(&r[0])),
+		})
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	data := i2cRdwrData{
+		msgs:  uintptr(unsafe.Pointer(&msgs[0])),
+		nmsgs: uint32(len(msgs)),
+	}
+	err := ioctl(v.bus.f.Fd(), i2cRdwr, uintptr(unsafe.Pointer(&data)))
+	// msgs (and the w/r buffers it points into) must stay alive until
+	// the ioctl has returned.
+	runtime.KeepAlive(msgs)
+	runtime.KeepAlive(w)
+	runtime.KeepAlive(r)
+
+	if err == syscall.ENOTTY {
+		v.log.Debugf("I2C_RDWR not supported, falling back to write+read")
+		return v.txFallback(w, r)
+	}
+	return err
+}
+
+// txFallback implements Tx as a plain write followed by a plain read,
+// for adapters that don't support I2C_RDWR. Callers must hold v.bus.mu
+// and have already selected the right slave address.
+func (v *I2C) txFallback(w, r []byte) error {
+	if len(w) > 0 {
+		if _, err := v.write(w); err != nil {
+			return err
+		}
+	}
+	if len(r) > 0 {
+		if _, err := v.read(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
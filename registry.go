@@ -0,0 +1,111 @@
+package i2c
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// busFD is a /dev/i2c-N file descriptor shared by every I2C handle
+// opened against that bus, plus the bookkeeping needed to serialize
+// access to it.
+type busFD struct {
+	mu       sync.Mutex
+	f        *os.File
+	addr     uint16
+	tenBit   bool
+	addrSet  bool
+	speedHz  int
+	speedSet bool
+	refs     int
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[int]*busFD{}
+)
+
+// acquireBus opens the shared file descriptor for bus if it isn't
+// already open, and bumps its refcount.
+func acquireBus(bus int) (*busFD, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	b, ok := registry[bus]
+	if !ok {
+		f, err := os.OpenFile(fmt.Sprintf("/dev/i2c-%d", bus), os.O_RDWR, 0600)
+		if err != nil {
+			return nil, err
+		}
+		b = &busFD{f: f}
+		registry[bus] = b
+	}
+	b.refs++
+	return b, nil
+}
+
+// Open opens (or reuses) the shared file descriptor for bus and
+// returns a handle addressing addr on it.
+func Open(bus int, addr uint8) (*I2C, error) {
+	b, err := acquireBus(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &I2C{bus: b, busNum: bus, addr: addr, log: logrus.New()}
+
+	b.mu.Lock()
+	err = v.setSlaveAddr(uint16(addr), false)
+	b.mu.Unlock()
+	if err != nil {
+		v.Close()
+		return nil, err
+	}
+	return v, nil
+}
+
+// setSlaveAddr issues I2C_TENBIT/I2C_SLAVE for addr, unless the bus fd
+// is already configured for it. Callers must hold v.bus.mu.
+func (v *I2C) setSlaveAddr(addr uint16, tenBit bool) error {
+	if v.bus.addrSet && v.bus.addr == addr && v.bus.tenBit == tenBit {
+		return nil
+	}
+	if tenBit != v.bus.tenBit {
+		arg := uintptr(0)
+		if tenBit {
+			arg = 1
+		}
+		if err := ioctl(v.bus.f.Fd(), i2cTenBit, arg); err != nil {
+			return err
+		}
+	}
+	if err := ioctl(v.bus.f.Fd(), i2cSlave, uintptr(addr)); err != nil {
+		return err
+	}
+	v.bus.addr = addr
+	v.bus.tenBit = tenBit
+	v.bus.addrSet = true
+	return nil
+}
+
+// setSlave is the 7-bit-address shorthand for setSlaveAddr. Callers
+// must hold v.bus.mu.
+func (v *I2C) setSlave(addr uint8) error {
+	return v.setSlaveAddr(uint16(addr), false)
+}
+
+// closeBus drops this handle's reference to its bus, closing the
+// shared fd once the last handle on it has gone away.
+func closeBus(bus int, b *busFD) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	b.refs--
+	if b.refs > 0 {
+		return nil
+	}
+	delete(registry, bus)
+	return b.f.Close()
+}
@@ -0,0 +1,55 @@
+package i2c
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SpeedHook sets the clock frequency (in Hz) of an i2c bus, in a
+// platform-specific way.
+type SpeedHook func(bus int, freq int) error
+
+var (
+	speedHookMu sync.Mutex
+	speedHook   SpeedHook = bcm2835SpeedHook
+)
+
+// RegisterSpeedHook installs hook as the SpeedHook used by
+// SetSpeedHz, replacing the built-in Raspberry Pi (bcm2835) hook.
+func RegisterSpeedHook(hook SpeedHook) error {
+	if hook == nil {
+		return fmt.Errorf("i2c: nil speed hook")
+	}
+	speedHookMu.Lock()
+	defer speedHookMu.Unlock()
+	speedHook = hook
+	return nil
+}
+
+// SetSpeedHz sets the clock frequency of the bus this handle was
+// opened on, via the registered SpeedHook. The hook runs at most once
+// per (bus, freq) pair; it's an error to request a different, already
+// conflicting speed for a bus.
+func (v *I2C) SetSpeedHz(freq int) error {
+	v.bus.mu.Lock()
+	defer v.bus.mu.Unlock()
+
+	if v.bus.speedSet {
+		if v.bus.speedHz == freq {
+			return nil
+		}
+		return fmt.Errorf("i2c: bus %d already running at %d Hz, cannot switch to %d Hz",
+			v.busNum, v.bus.speedHz, freq)
+	}
+
+	speedHookMu.Lock()
+	hook := speedHook
+	speedHookMu.Unlock()
+
+	if err := hook(v.busNum, freq); err != nil {
+		return err
+	}
+	v.bus.speedHz = freq
+	v.bus.speedSet = true
+	return nil
+}
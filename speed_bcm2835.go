@@ -0,0 +1,77 @@
+package i2c
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// BCM2835 (Raspberry Pi 1/Zero) physical addresses. bcm2835ClkDivOff
+// is the offset of the BSC controller's CDIV register within its own
+// register block; see the BCM2835 ARM peripherals datasheet, section
+// 3.2 "BSC Registers".
+const (
+	bcm2835PeriBase  = 0x20000000
+	bcm2835Bsc0Base  = bcm2835PeriBase + 0x205000
+	bcm2835Bsc1Base  = bcm2835PeriBase + 0x804000
+	bcm2835ClkDivOff = 0x14
+	bcm2835CoreClkHz = 150000000
+)
+
+// bcm2835BscBase maps a /dev/i2c-N bus number to the physical base
+// address of the BSC controller backing it. Only the two
+// user-facing controllers on the 40-pin header are known; any other
+// bus number is rejected rather than silently reprogramming the wrong
+// controller's clock.
+func bcm2835BscBase(bus int) (uintptr, error) {
+	switch bus {
+	case 0:
+		return bcm2835Bsc0Base, nil
+	case 1:
+		return bcm2835Bsc1Base, nil
+	default:
+		return 0, fmt.Errorf("i2c: no known BSC base address for bus %d", bus)
+	}
+}
+
+// bcm2835SpeedHook is the built-in SpeedHook for BCM2835-based
+// Raspberry Pi boards (Pi 1/Zero), poking the BSC clock divider
+// directly via /dev/mem. It always uses the BCM2835 peripheral base
+// above; later SoCs with a different base are not handled.
+func bcm2835SpeedHook(bus int, freq int) error {
+	if freq <= 0 {
+		return fmt.Errorf("i2c: invalid speed %d Hz", freq)
+	}
+
+	base, err := bcm2835BscBase(bus)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile("/dev/mem", os.O_RDWR|os.O_SYNC, 0)
+	if err != nil {
+		return fmt.Errorf("i2c: opening /dev/mem for speed hook: %w", err)
+	}
+	defer f.Close()
+
+	pageSize := os.Getpagesize()
+	mem, err := syscall.Mmap(int(f.Fd()), int64(base), pageSize,
+		syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return fmt.Errorf("i2c: mmap BSC registers for speed hook: %w", err)
+	}
+	defer syscall.Munmap(mem)
+
+	div := bcm2835CoreClkHz / freq
+	if div%2 != 0 {
+		div++
+	}
+	if div < 2 {
+		div = 2
+	}
+
+	reg := (*uint32)(unsafe.Pointer(&mem[bcm2835ClkDivOff]))
+	*reg = uint32(div)
+	return nil
+}
@@ -0,0 +1,48 @@
+package i2c
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// i2cTenBit toggles 10-bit addressing mode for the next I2C_SLAVE
+	// on this fd.
+	i2cTenBit = 0x0704
+)
+
+// NewI2C10Bit opens a connection to an i2c device using a 10-bit slave
+// address (0x000-0x3FF). Returns an error if the adapter doesn't
+// advertise I2C_FUNC_10BIT_ADDR support.
+func NewI2C10Bit(addr uint16, bus int) (*I2C, error) {
+	if addr > 0x3FF {
+		return nil, fmt.Errorf("i2c: 10-bit address 0x%X out of range", addr)
+	}
+
+	b, err := acquireBus(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &I2C{bus: b, busNum: bus, addr16: addr, tenBit: true, log: logrus.New()}
+
+	funcs, err := v.Funcs()
+	if err != nil {
+		v.Close()
+		return nil, fmt.Errorf("i2c: probing adapter capabilities: %w", err)
+	}
+	if funcs&I2CFunc10BitAddr == 0 {
+		v.Close()
+		return nil, fmt.Errorf("i2c: adapter on bus %d does not support 10-bit addressing", bus)
+	}
+
+	b.mu.Lock()
+	err = v.setSlaveAddr(addr, true)
+	b.mu.Unlock()
+	if err != nil {
+		v.Close()
+		return nil, err
+	}
+	return v, nil
+}